@@ -0,0 +1,106 @@
+package counter
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+)
+
+// CreateCounters bulk-creates counters, pairing each name with its initial
+// value by index. On Postgres this streams rows through a COPY for
+// throughput; other dialects fall back to a single multi-row INSERT.
+func (co CounterOperator) CreateCounters(ctx context.Context, tx *gorm.DB, names []string, initial []uint32) error {
+	if len(names) != len(initial) {
+		return fmt.Errorf("counter: names and initial must be the same length")
+	}
+	if len(names) == 0 {
+		return nil
+	}
+
+	switch tx.Dialector.Name() {
+	case "postgres":
+		return co.createCountersCopy(ctx, tx, names, initial)
+	default:
+		return co.createCountersInsert(ctx, tx, names, initial)
+	}
+}
+
+// copyPreparer is the part of *sql.Tx that copyCounters needs.
+// createCountersCopy always hands it a *sql.Tx: either the one tx is already
+// bound to, or one it opens itself on a dedicated connection.
+type copyPreparer interface {
+	PrepareContext(ctx context.Context, query string) (*sql.Stmt, error)
+}
+
+// createCountersCopy implements CreateCounters via a Postgres COPY. When tx
+// is already inside a transaction (e.g. the caller wrapped CreateCounters
+// together with other writes in db.Transaction), the COPY runs on that same
+// *sql.Tx so it composes with the caller's commit/rollback instead of
+// racing an independent connection. Only when tx is the top-level *gorm.DB
+// does this open and own a transaction itself.
+func (co CounterOperator) createCountersCopy(ctx context.Context, tx *gorm.DB, names []string, initial []uint32) error {
+	switch connPool := tx.Statement.ConnPool.(type) {
+	case *sql.Tx:
+		return copyCounters(ctx, connPool, names, initial)
+	case *sql.DB:
+		conn, err := connPool.Conn(ctx)
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+
+		txn, err := conn.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+
+		if err := copyCounters(ctx, txn, names, initial); err != nil {
+			txn.Rollback()
+			return err
+		}
+
+		return txn.Commit()
+	default:
+		return fmt.Errorf("counter: unsupported connection pool %T for COPY", connPool)
+	}
+}
+
+// copyCounters streams names/initial through a COPY ... FROM STDIN on p. It
+// never commits or rolls back: that is the responsibility of whichever
+// caller owns p's transaction.
+func copyCounters(ctx context.Context, p copyPreparer, names []string, initial []uint32) error {
+	stmt, err := p.PrepareContext(ctx, pq.CopyIn("counters", "name", "value", "created_at", "updated_at"))
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for i, name := range names {
+		if _, err := stmt.ExecContext(ctx, name, initial[i], now, now); err != nil {
+			stmt.Close()
+			return err
+		}
+	}
+
+	if _, err := stmt.ExecContext(ctx); err != nil {
+		stmt.Close()
+		return err
+	}
+
+	return stmt.Close()
+}
+
+// createCountersInsert implements CreateCounters as a single multi-row
+// INSERT, for dialects without COPY support.
+func (co CounterOperator) createCountersInsert(ctx context.Context, tx *gorm.DB, names []string, initial []uint32) error {
+	counters := make([]Counter, len(names))
+	for i, name := range names {
+		counters[i] = Counter{Name: name, Value: initial[i]}
+	}
+
+	return tx.WithContext(ctx).Create(&counters).Error
+}
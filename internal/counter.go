@@ -2,6 +2,9 @@ package counter
 
 import (
 	"context"
+	"errors"
+	"math"
+	"sync"
 
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
@@ -18,7 +21,10 @@ type CounterOperator struct{}
 // Create a new named counter.
 func (co CounterOperator) CreateCounter(ctx context.Context, tx *gorm.DB, name string) (Counter, error) {
 	counter := Counter{Name: name, Value: 0}
-	if err := tx.Create(&counter).Error; err != nil {
+	if err := tx.WithContext(ctx).Create(&counter).Error; err != nil {
+		if isUniqueViolation(err) {
+			return Counter{}, ErrCounterExists
+		}
 		return Counter{}, err
 	}
 	return counter, nil
@@ -26,11 +32,20 @@ func (co CounterOperator) CreateCounter(ctx context.Context, tx *gorm.DB, name s
 
 // Get the next value of the named counter.
 func (co CounterOperator) Next(ctx context.Context, tx *gorm.DB, name string) (Counter, error) {
+	tx = tx.WithContext(ctx)
+
 	var counter Counter
 	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("name = ?", name).First(&counter).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return Counter{}, ErrCounterNotFound
+		}
 		return Counter{}, err
 	}
 
+	if counter.Value == math.MaxUint32 {
+		return Counter{}, ErrCounterOverflow
+	}
+
 	counter.Value++
 	if err := tx.Save(&counter).Error; err != nil {
 		return Counter{}, err
@@ -41,5 +56,88 @@ func (co CounterOperator) Next(ctx context.Context, tx *gorm.DB, name string) (C
 
 // Delete the named counter.
 func (co CounterOperator) DeleteCounter(ctx context.Context, tx *gorm.DB, name string) error {
-	return tx.Where("name = ?", name).Delete(&Counter{}).Error
+	result := tx.WithContext(ctx).Where("name = ?", name).Delete(&Counter{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrCounterNotFound
+	}
+	return nil
+}
+
+// Reserve a contiguous, half-open range [start, end) of n values for the
+// named counter, advancing its persisted Value by n in a single row-lock
+// acquisition. The range is monotonic but not dense: if the caller does not
+// consume every value in the range (e.g. a process crash), the unused tail
+// is simply lost.
+func (co CounterOperator) NextBatch(ctx context.Context, tx *gorm.DB, name string, n uint32) (uint32, uint32, error) {
+	tx = tx.WithContext(ctx)
+
+	var counter Counter
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("name = ?", name).First(&counter).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, 0, ErrCounterNotFound
+		}
+		return 0, 0, err
+	}
+
+	if n > math.MaxUint32-counter.Value {
+		return 0, 0, ErrCounterOverflow
+	}
+
+	start := counter.Value
+	counter.Value += n
+	if err := tx.Save(&counter).Error; err != nil {
+		return 0, 0, err
+	}
+
+	return start, counter.Value, nil
+}
+
+// Allocator hands out sequential counter values from an in-memory block
+// reserved via NextBatch, only touching the database once the block is
+// exhausted. This trades dense, per-ID row locks for one row-lock
+// acquisition per BlockSize IDs.
+type Allocator struct {
+	DB        *gorm.DB
+	Name      string
+	BlockSize uint32
+
+	mu   sync.Mutex
+	next uint32
+	end  uint32
+}
+
+// NewAllocator builds an Allocator that reserves blockSize values at a time
+// for the named counter.
+func NewAllocator(db *gorm.DB, name string, blockSize uint32) *Allocator {
+	return &Allocator{DB: db, Name: name, BlockSize: blockSize}
+}
+
+// Next returns the next value for the allocator's counter, reserving a new
+// block via NextBatch when the current one is exhausted.
+func (a *Allocator) Next(ctx context.Context) (uint32, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.next >= a.end {
+		co := CounterOperator{}
+
+		var start, end uint32
+		err := a.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			var err error
+			start, end, err = co.NextBatch(ctx, tx, a.Name, a.BlockSize)
+			return err
+		})
+		if err != nil {
+			return 0, err
+		}
+
+		a.next, a.end = start, end
+	}
+
+	v := a.next
+	a.next++
+	return v, nil
 }
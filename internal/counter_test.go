@@ -3,6 +3,7 @@ package counter
 import (
 	"context"
 	"errors"
+	"math"
 	"os"
 	"path/filepath"
 	"sync"
@@ -427,6 +428,217 @@ func TestDelete(t *testing.T) {
 	}
 }
 
+// NextBatch reserves a contiguous, half-open range and advances the
+// counter by the full batch size.
+func TestNextBatch(t *testing.T) {
+	for _, p := range parameters {
+		t.Run(p.name, func(t *testing.T) {
+			db, cleanup := p.initFn(t)
+			defer cleanup()
+
+			co := CounterOperator{}
+
+			_, err := co.CreateCounter(context.Background(), db, "n0")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			start, end, err := co.NextBatch(context.Background(), db, "n0", 10)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if start != 0 || end != 10 {
+				t.Fatalf("expected [0, 10), got [%d, %d)", start, end)
+			}
+
+			start, end, err = co.NextBatch(context.Background(), db, "n0", 5)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if start != 10 || end != 15 {
+				t.Fatalf("expected [10, 15), got [%d, %d)", start, end)
+			}
+		})
+	}
+}
+
+// NextBatch against a counter that does not exist returns
+// ErrCounterNotFound.
+func TestNextBatchNotFound(t *testing.T) {
+	for _, p := range parameters {
+		t.Run(p.name, func(t *testing.T) {
+			db, cleanup := p.initFn(t)
+			defer cleanup()
+
+			co := CounterOperator{}
+
+			_, _, err := co.NextBatch(context.Background(), db, "n0", 5)
+			if !errors.Is(err, ErrCounterNotFound) {
+				t.Fatalf("expected ErrCounterNotFound, got %v", err)
+			}
+		})
+	}
+}
+
+// NextBatch returns ErrCounterOverflow instead of wrapping past
+// math.MaxUint32, and does not advance or persist the counter when it does.
+func TestNextBatchOverflow(t *testing.T) {
+	for _, p := range parameters {
+		t.Run(p.name, func(t *testing.T) {
+			db, cleanup := p.initFn(t)
+			defer cleanup()
+
+			co := CounterOperator{}
+
+			_, err := co.CreateCounter(context.Background(), db, "n0")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err := db.Model(&Counter{}).Where("name = ?", "n0").Update("value", uint32(math.MaxUint32-2)).Error; err != nil {
+				t.Fatal(err)
+			}
+
+			_, _, err = co.NextBatch(context.Background(), db, "n0", 5)
+			if !errors.Is(err, ErrCounterOverflow) {
+				t.Fatalf("expected ErrCounterOverflow, got %v", err)
+			}
+
+			v, err := co.Peek(context.Background(), db, "n0")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if v != math.MaxUint32-2 {
+				t.Fatalf("expected unchanged value %d, got %d", uint32(math.MaxUint32-2), v)
+			}
+		})
+	}
+}
+
+// NextBatch honors a canceled context instead of completing the query.
+func TestNextBatchContextCanceled(t *testing.T) {
+	for _, p := range parameters {
+		t.Run(p.name, func(t *testing.T) {
+			db, cleanup := p.initFn(t)
+			defer cleanup()
+
+			co := CounterOperator{}
+
+			_, err := co.CreateCounter(context.Background(), db, "n0")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			_, _, err = co.NextBatch(ctx, db, "n0", 5)
+			if err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
+
+// A rolled-back NextBatch does not advance the counter and the reserved
+// range is never observed by subsequent callers.
+func TestNextBatchRollback(t *testing.T) {
+	for _, p := range parameters {
+		t.Run(p.name, func(t *testing.T) {
+			db, cleanup := p.initFn(t)
+			defer cleanup()
+
+			co := CounterOperator{}
+
+			_, err := co.CreateCounter(context.Background(), db, "n0")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = db.Transaction(func(tx *gorm.DB) error {
+				if _, _, err := co.NextBatch(context.Background(), tx, "n0", 10); err != nil {
+					t.Fatal(err)
+				}
+				return errors.New("abort")
+			})
+			if err == nil {
+				t.Fatal("expected error")
+			}
+
+			start, end, err := co.NextBatch(context.Background(), db, "n0", 1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if start != 0 || end != 1 {
+				t.Fatalf("expected [0, 1), got [%d, %d)", start, end)
+			}
+		})
+	}
+}
+
+// Concurrent allocators sharing a counter name never hand out duplicate
+// values, even as each exhausts and replenishes its in-memory block.
+func TestAllocatorConcurrentNoDuplicates(t *testing.T) {
+	const nAllocators = 4
+	const nPerAllocator = 50
+
+	for _, p := range parameters {
+		t.Run(p.name, func(t *testing.T) {
+			db, cleanup := p.initFn(t)
+			defer cleanup()
+
+			co := CounterOperator{}
+
+			_, err := co.CreateCounter(context.Background(), db, "n0")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			values := make(map[uint32]bool)
+
+			var lk sync.Mutex
+			var wg sync.WaitGroup
+
+			ec := make(chan error, nAllocators*nPerAllocator)
+
+			wg.Add(nAllocators)
+			for i := 0; i < nAllocators; i++ {
+				go func() {
+					defer wg.Done()
+
+					a := NewAllocator(db, "n0", 7)
+					for j := 0; j < nPerAllocator; j++ {
+						v, err := a.Next(context.Background())
+						if err != nil {
+							ec <- err
+							return
+						}
+
+						lk.Lock()
+						if values[v] {
+							lk.Unlock()
+							ec <- errors.New("duplicate value")
+							return
+						}
+						values[v] = true
+						lk.Unlock()
+					}
+				}()
+			}
+
+			wg.Wait()
+
+			if len(ec) > 0 {
+				t.Fatal(<-ec)
+			}
+
+			if len(values) != nAllocators*nPerAllocator {
+				t.Fatalf("expected %d distinct values, got %d", nAllocators*nPerAllocator, len(values))
+			}
+		})
+	}
+}
+
 func newSqliteDB(t *testing.T) (*gorm.DB, func()) {
 	dir, err := os.MkdirTemp("", "test-*")
 	if err != nil {
@@ -439,7 +651,7 @@ func newSqliteDB(t *testing.T) (*gorm.DB, func()) {
 		t.Fatal(err)
 	}
 
-	if err = db.AutoMigrate(&Counter{}); err != nil {
+	if err = Migrate(context.Background(), db); err != nil {
 		os.RemoveAll(dir)
 		t.Fatal(err)
 	}
@@ -462,9 +674,11 @@ func newPostgresDB(t *testing.T) (*gorm.DB, func()) {
 		t.Fatal(err)
 	}
 
-	if err = db.AutoMigrate(&Counter{}); err != nil {
+	if err = Migrate(context.Background(), db); err != nil {
 		t.Fatal(err)
 	}
 
-	return db, func() { db.Migrator().DropTable(&Counter{}) }
+	return db, func() {
+		db.Migrator().DropTable(&Counter{}, &schemaMigration{})
+	}
 }
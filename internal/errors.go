@@ -0,0 +1,36 @@
+package counter
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// Sentinel errors returned by CounterOperator methods. Consumers should
+// use errors.Is rather than pattern-matching gorm internals.
+var (
+	// ErrCounterNotFound is returned when the named counter does not exist.
+	ErrCounterNotFound = errors.New("counter: not found")
+
+	// ErrCounterExists is returned by CreateCounter when the named counter
+	// already exists.
+	ErrCounterExists = errors.New("counter: already exists")
+
+	// ErrCounterOverflow is returned by Next when advancing the counter
+	// would overflow uint32.
+	ErrCounterOverflow = errors.New("counter: value would overflow")
+)
+
+// isUniqueViolation reports whether err is a unique-constraint violation
+// from the underlying driver. CreateCounter relies on this rather than a
+// separate existence check, so that concurrent creation of the same name
+// is resolved atomically by the database instead of racing on two
+// statements.
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return pqErr.Code == "23505"
+	}
+	return strings.Contains(err.Error(), "UNIQUE constraint")
+}
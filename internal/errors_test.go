@@ -0,0 +1,145 @@
+package counter
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync"
+	"testing"
+)
+
+// Creating a counter with a name that already exists returns
+// ErrCounterExists.
+func TestCreateCounterExists(t *testing.T) {
+	for _, p := range parameters {
+		t.Run(p.name, func(t *testing.T) {
+			db, cleanup := p.initFn(t)
+			defer cleanup()
+
+			co := CounterOperator{}
+
+			_, err := co.CreateCounter(context.Background(), db, "n0")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = co.CreateCounter(context.Background(), db, "n0")
+			if !errors.Is(err, ErrCounterExists) {
+				t.Fatalf("expected ErrCounterExists, got %v", err)
+			}
+		})
+	}
+}
+
+// Concurrent CreateCounter calls racing to create the same new name never
+// surface a raw driver error: exactly one succeeds and every other caller
+// sees ErrCounterExists.
+func TestCreateCounterExistsConcurrent(t *testing.T) {
+	const nThreads = 10
+
+	for _, p := range parameters {
+		t.Run(p.name, func(t *testing.T) {
+			db, cleanup := p.initFn(t)
+			defer cleanup()
+
+			co := CounterOperator{}
+
+			var successes, conflicts, other int32
+			var lk sync.Mutex
+			var wg sync.WaitGroup
+
+			wg.Add(nThreads)
+			for i := 0; i < nThreads; i++ {
+				go func() {
+					defer wg.Done()
+
+					_, err := co.CreateCounter(context.Background(), db, "n0")
+
+					lk.Lock()
+					defer lk.Unlock()
+
+					switch {
+					case err == nil:
+						successes++
+					case errors.Is(err, ErrCounterExists):
+						conflicts++
+					default:
+						other++
+					}
+				}()
+			}
+
+			wg.Wait()
+
+			if successes != 1 {
+				t.Fatalf("expected exactly 1 success, got %d", successes)
+			}
+			if conflicts != nThreads-1 {
+				t.Fatalf("expected %d ErrCounterExists, got %d", nThreads-1, conflicts)
+			}
+			if other != 0 {
+				t.Fatalf("expected 0 untranslated errors, got %d", other)
+			}
+		})
+	}
+}
+
+// Next against a counter that does not exist returns ErrCounterNotFound.
+func TestNextCounterNotFound(t *testing.T) {
+	for _, p := range parameters {
+		t.Run(p.name, func(t *testing.T) {
+			db, cleanup := p.initFn(t)
+			defer cleanup()
+
+			co := CounterOperator{}
+
+			_, err := co.Next(context.Background(), db, "n0")
+			if !errors.Is(err, ErrCounterNotFound) {
+				t.Fatalf("expected ErrCounterNotFound, got %v", err)
+			}
+		})
+	}
+}
+
+// Deleting a counter that does not exist returns ErrCounterNotFound.
+func TestDeleteCounterNotFound(t *testing.T) {
+	for _, p := range parameters {
+		t.Run(p.name, func(t *testing.T) {
+			db, cleanup := p.initFn(t)
+			defer cleanup()
+
+			co := CounterOperator{}
+
+			err := co.DeleteCounter(context.Background(), db, "n0")
+			if !errors.Is(err, ErrCounterNotFound) {
+				t.Fatalf("expected ErrCounterNotFound, got %v", err)
+			}
+		})
+	}
+}
+
+// Next returns ErrCounterOverflow instead of wrapping past math.MaxUint32.
+func TestNextOverflow(t *testing.T) {
+	for _, p := range parameters {
+		t.Run(p.name, func(t *testing.T) {
+			db, cleanup := p.initFn(t)
+			defer cleanup()
+
+			co := CounterOperator{}
+
+			_, err := co.CreateCounter(context.Background(), db, "n0")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err := db.Model(&Counter{}).Where("name = ?", "n0").Update("value", uint32(math.MaxUint32)).Error; err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = co.Next(context.Background(), db, "n0")
+			if !errors.Is(err, ErrCounterOverflow) {
+				t.Fatalf("expected ErrCounterOverflow, got %v", err)
+			}
+		})
+	}
+}
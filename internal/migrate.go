@@ -0,0 +1,173 @@
+package counter
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/turingcompl33t/counter/internal/migrations"
+	"gorm.io/gorm"
+)
+
+// schemaMigration records that the migration numbered ID has been applied.
+type schemaMigration struct {
+	ID        uint `gorm:"primaryKey;autoIncrement:false"`
+	AppliedAt time.Time
+}
+
+func (schemaMigration) TableName() string {
+	return "counter_schema_migrations"
+}
+
+// migration is a single numbered, dialect-specific schema change.
+type migration struct {
+	version uint
+	up      string
+	down    string
+}
+
+// Migrate applies all pending migrations to db, in order.
+func Migrate(ctx context.Context, db *gorm.DB) error {
+	all, err := loadMigrations(db)
+	if err != nil {
+		return err
+	}
+	if len(all) == 0 {
+		return nil
+	}
+
+	return MigrateTo(ctx, db, all[len(all)-1].version)
+}
+
+// MigrateTo migrates db to exactly the given version, running up
+// migrations if version is ahead of the current schema or down migrations
+// if it is behind. All migrations needed to reach version are applied in a
+// single transaction.
+func MigrateTo(ctx context.Context, db *gorm.DB, version uint) error {
+	all, err := loadMigrations(db)
+	if err != nil {
+		return err
+	}
+
+	db = db.WithContext(ctx)
+
+	if err := db.AutoMigrate(&schemaMigration{}); err != nil {
+		return err
+	}
+
+	var applied []schemaMigration
+	if err := db.Order("id").Find(&applied).Error; err != nil {
+		return err
+	}
+
+	var current uint
+	if len(applied) > 0 {
+		current = applied[len(applied)-1].ID
+	}
+
+	if version == current {
+		return nil
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if version > current {
+			for _, m := range all {
+				if m.version <= current || m.version > version {
+					continue
+				}
+				if err := tx.Exec(m.up).Error; err != nil {
+					return fmt.Errorf("counter: apply migration %d: %w", m.version, err)
+				}
+				if err := tx.Create(&schemaMigration{ID: m.version, AppliedAt: time.Now()}).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+
+		for i := len(all) - 1; i >= 0; i-- {
+			m := all[i]
+			if m.version > current || m.version <= version {
+				continue
+			}
+			if err := tx.Exec(m.down).Error; err != nil {
+				return fmt.Errorf("counter: revert migration %d: %w", m.version, err)
+			}
+			if err := tx.Where("id = ?", m.version).Delete(&schemaMigration{}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// loadMigrations reads and parses the embedded migration files for db's
+// dialect, sorted by version ascending.
+func loadMigrations(db *gorm.DB) ([]migration, error) {
+	var tree fs.FS
+	var err error
+
+	switch db.Dialector.Name() {
+	case "postgres":
+		tree, err = fs.Sub(migrations.Postgres, "postgres")
+	default:
+		tree, err = fs.Sub(migrations.SQLite, "sqlite")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := fs.ReadDir(tree, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[uint]*migration)
+	for _, e := range entries {
+		name := e.Name()
+		if !strings.HasSuffix(name, ".sql") {
+			continue
+		}
+
+		base := strings.TrimSuffix(name, ".sql")
+		version, direction, ok := strings.Cut(base, "_")
+		if !ok {
+			continue
+		}
+
+		v, err := strconv.ParseUint(version, 10, 32)
+		if err != nil {
+			continue
+		}
+
+		content, err := fs.ReadFile(tree, name)
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byVersion[uint(v)]
+		if !ok {
+			m = &migration{version: uint(v)}
+			byVersion[uint(v)] = m
+		}
+
+		switch {
+		case strings.HasSuffix(direction, ".up"):
+			m.up = string(content)
+		case strings.HasSuffix(direction, ".down"):
+			m.down = string(content)
+		}
+	}
+
+	out := make([]migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		out = append(out, *m)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].version < out[j].version })
+
+	return out, nil
+}
@@ -0,0 +1,35 @@
+package counter
+
+import (
+	"context"
+	"testing"
+)
+
+// MigrateTo can roll a schema forward and back, and Counter operations
+// fail once the schema has been reverted.
+func TestMigrateTo(t *testing.T) {
+	for _, p := range parameters {
+		t.Run(p.name, func(t *testing.T) {
+			db, cleanup := p.initFn(t)
+			defer cleanup()
+
+			co := CounterOperator{}
+
+			if err := MigrateTo(context.Background(), db, 0); err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := co.CreateCounter(context.Background(), db, "n0"); err == nil {
+				t.Fatal("expected error with schema reverted")
+			}
+
+			if err := MigrateTo(context.Background(), db, 1); err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := co.CreateCounter(context.Background(), db, "n0"); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
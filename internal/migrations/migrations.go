@@ -0,0 +1,11 @@
+// Package migrations embeds the versioned, dialect-specific SQL files
+// applied by counter.Migrate and counter.MigrateTo.
+package migrations
+
+import "embed"
+
+//go:embed postgres/*.sql
+var Postgres embed.FS
+
+//go:embed sqlite/*.sql
+var SQLite embed.FS
@@ -0,0 +1,47 @@
+package counter
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+// Peek returns the current value of the named counter without taking a
+// row lock.
+func (co CounterOperator) Peek(ctx context.Context, tx *gorm.DB, name string) (uint32, error) {
+	var counter Counter
+	if err := tx.WithContext(ctx).Where("name = ?", name).First(&counter).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, ErrCounterNotFound
+		}
+		return 0, err
+	}
+
+	return counter.Value, nil
+}
+
+// WithSnapshot runs fn inside a REPEATABLE READ, READ ONLY transaction on
+// Postgres, giving callers a consistent view across multiple counters
+// without blocking writers. Other dialects fall back to a plain
+// transaction.
+func (co CounterOperator) WithSnapshot(ctx context.Context, db *gorm.DB, fn func(tx *gorm.DB) error) error {
+	opts := &sql.TxOptions{}
+	if db.Dialector.Name() == "postgres" {
+		opts.Isolation = sql.LevelRepeatableRead
+		opts.ReadOnly = true
+	}
+
+	tx := db.WithContext(ctx).Begin(opts)
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
@@ -0,0 +1,94 @@
+package counter
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+// Peek reads the current value without requiring a held lock.
+func TestPeek(t *testing.T) {
+	for _, p := range parameters {
+		t.Run(p.name, func(t *testing.T) {
+			db, cleanup := p.initFn(t)
+			defer cleanup()
+
+			co := CounterOperator{}
+
+			_, err := co.CreateCounter(context.Background(), db, "n0")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for i := 0; i < 3; i++ {
+				if _, err := co.Next(context.Background(), db, "n0"); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			v, err := co.Peek(context.Background(), db, "n0")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if v != 3 {
+				t.Fatalf("expected 3, got %d", v)
+			}
+		})
+	}
+}
+
+// Peek on a counter that does not exist returns ErrCounterNotFound.
+func TestPeekNotFound(t *testing.T) {
+	for _, p := range parameters {
+		t.Run(p.name, func(t *testing.T) {
+			db, cleanup := p.initFn(t)
+			defer cleanup()
+
+			co := CounterOperator{}
+
+			_, err := co.Peek(context.Background(), db, "n0")
+			if !errors.Is(err, ErrCounterNotFound) {
+				t.Fatalf("expected ErrCounterNotFound, got %v", err)
+			}
+		})
+	}
+}
+
+// WithSnapshot exposes a consistent read across multiple counters.
+func TestWithSnapshot(t *testing.T) {
+	for _, p := range parameters {
+		t.Run(p.name, func(t *testing.T) {
+			db, cleanup := p.initFn(t)
+			defer cleanup()
+
+			co := CounterOperator{}
+
+			if _, err := co.CreateCounter(context.Background(), db, "n0"); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := co.CreateCounter(context.Background(), db, "n1"); err != nil {
+				t.Fatal(err)
+			}
+
+			var v0, v1 uint32
+			err := co.WithSnapshot(context.Background(), db, func(tx *gorm.DB) error {
+				var err error
+				v0, err = co.Peek(context.Background(), tx, "n0")
+				if err != nil {
+					return err
+				}
+				v1, err = co.Peek(context.Background(), tx, "n1")
+				return err
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if v0 != 0 || v1 != 0 {
+				t.Fatalf("expected (0, 0), got (%d, %d)", v0, v1)
+			}
+		})
+	}
+}
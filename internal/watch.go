@@ -0,0 +1,133 @@
+package counter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// CounterEvent describes a single change to a named counter, as observed
+// via Watch.
+type CounterEvent struct {
+	Name  string
+	Value uint32
+	Op    string
+}
+
+// watchChannel is the Postgres NOTIFY channel that the counters table
+// trigger publishes to.
+const watchChannel = "counters"
+
+// Watch streams CounterEvents for the named counter as it changes. On
+// Postgres this is backed by LISTEN/NOTIFY via the trigger installed on the
+// counters table by the 0002_watch_trigger migration; other dialects fall
+// back to polling the row on an interval. The returned channel is closed
+// once ctx is done.
+func (co CounterOperator) Watch(ctx context.Context, db *gorm.DB, name string) (<-chan CounterEvent, error) {
+	switch db.Dialector.Name() {
+	case "postgres":
+		return co.watchPostgres(ctx, db, name)
+	default:
+		return co.watchPoll(ctx, db, name)
+	}
+}
+
+// watchPostgres implements Watch using LISTEN/NOTIFY.
+func (co CounterOperator) watchPostgres(ctx context.Context, db *gorm.DB, name string) (<-chan CounterEvent, error) {
+	dialector, ok := db.Dialector.(*postgres.Dialector)
+	if !ok || dialector.Config == nil {
+		return nil, fmt.Errorf("counter: watch requires a postgres dialector")
+	}
+
+	listener := pq.NewListener(dialector.Config.DSN, 10*time.Second, time.Minute, nil)
+	if err := listener.Listen(watchChannel); err != nil {
+		listener.Close()
+		return nil, err
+	}
+
+	events := make(chan CounterEvent)
+
+	go func() {
+		defer close(events)
+		defer listener.Close()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case n := <-listener.Notify:
+				if n == nil {
+					continue
+				}
+
+				var payload struct {
+					Name  string `json:"name"`
+					Value uint32 `json:"value"`
+					Op    string `json:"op"`
+				}
+				if err := json.Unmarshal([]byte(n.Extra), &payload); err != nil {
+					continue
+				}
+				if payload.Name != name {
+					continue
+				}
+
+				select {
+				case events <- CounterEvent{Name: payload.Name, Value: payload.Value, Op: payload.Op}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return events, nil
+}
+
+// watchPoll implements Watch for dialects without LISTEN/NOTIFY support
+// (e.g. SQLite) by re-reading the row on a fixed interval and emitting an
+// event for the first value it reads and then whenever the observed value
+// changes.
+func (co CounterOperator) watchPoll(ctx context.Context, db *gorm.DB, name string) (<-chan CounterEvent, error) {
+	events := make(chan CounterEvent)
+
+	go func() {
+		defer close(events)
+
+		ticker := time.NewTicker(200 * time.Millisecond)
+		defer ticker.Stop()
+
+		var last uint32
+		seen := false
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				var counter Counter
+				if err := db.Where("name = ?", name).First(&counter).Error; err != nil {
+					continue
+				}
+
+				if !seen || counter.Value != last {
+					seen = true
+					last = counter.Value
+
+					select {
+					case events <- CounterEvent{Name: name, Value: counter.Value, Op: "UPDATE"}:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+		}
+	}()
+
+	return events, nil
+}
@@ -0,0 +1,48 @@
+package counter
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// Watch delivers an event after the named counter is advanced.
+func TestWatch(t *testing.T) {
+	for _, p := range parameters {
+		t.Run(p.name, func(t *testing.T) {
+			db, cleanup := p.initFn(t)
+			defer cleanup()
+
+			co := CounterOperator{}
+
+			_, err := co.CreateCounter(context.Background(), db, "n0")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			events, err := co.Watch(ctx, db, "n0")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := co.Next(context.Background(), db, "n0"); err != nil {
+				t.Fatal(err)
+			}
+
+			select {
+			case e, ok := <-events:
+				if !ok {
+					t.Fatal("events channel closed unexpectedly")
+				}
+				if e.Name != "n0" || e.Value != 1 {
+					t.Fatalf("expected {n0 1}, got %+v", e)
+				}
+			case <-ctx.Done():
+				t.Fatal("timed out waiting for event")
+			}
+		})
+	}
+}